@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// outputFormat is the result of negotiating a request's Accept header
+// against the formats timeHandler can serve from the same cached Summary.
+type outputFormat int
+
+const (
+	formatHTML outputFormat = iota
+	formatJSON
+	formatAtom
+)
+
+// negotiateFormat inspects the Accept header and picks the best matching
+// format, defaulting to HTML when Accept is absent, "*/*", or unrecognized.
+func negotiateFormat(r *http.Request) outputFormat {
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "application/atom+xml"):
+		return formatAtom
+	case strings.Contains(accept, "application/json"):
+		return formatJSON
+	default:
+		return formatHTML
+	}
+}
+
+// writeSummaryJSON writes summary as the {updated_at, sources: [...]} JSON
+// document also served at /api/summary.json.
+func writeSummaryJSON(w http.ResponseWriter, summary Summary) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		log.Printf("Error encoding summary JSON: %v", err)
+	}
+}
+
+// summaryJSONHandler serves /api/summary.json directly, regardless of Accept.
+func summaryJSONHandler(w http.ResponseWriter, r *http.Request) {
+	summary, err := getLatestSummary(r.Context())
+	if err != nil {
+		log.Printf("Handler warning: serving potentially stale summary JSON due to error: %v", err)
+	}
+	writeSummaryJSON(w, summary)
+}
+
+// atomFeedOut is the root of a minimal Atom 1.0 feed document.
+type atomFeedOut struct {
+	XMLName xml.Name       `xml:"feed"`
+	Xmlns   string         `xml:"xmlns,attr"`
+	Title   string         `xml:"title"`
+	ID      string         `xml:"id"`
+	Updated string         `xml:"updated"`
+	Entries []atomEntryOut `xml:"entry"`
+}
+
+type atomEntryOut struct {
+	Title   string      `xml:"title"`
+	Link    atomLinkOut `xml:"link"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Source  string      `xml:"source"`
+}
+
+type atomLinkOut struct {
+	Href string `xml:"href,attr"`
+}
+
+// buildAtomFeed re-emits summary's merged, chronologically sorted items as
+// a single Atom feed, so this service can itself be consumed by other
+// aggregators.
+func buildAtomFeed(summary Summary) atomFeedOut {
+	items := mergedItems(summary)
+
+	feed := atomFeedOut{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		Title:   "Aggregated News",
+		ID:      "urn:time-demo:summary",
+		Updated: summary.UpdatedAt.UTC().Format(time.RFC3339),
+		Entries: make([]atomEntryOut, 0, len(items)),
+	}
+	for _, item := range items {
+		updated := item.Published
+		if updated == "" {
+			updated = feed.Updated
+		}
+		feed.Entries = append(feed.Entries, atomEntryOut{
+			Title:   item.Title,
+			Link:    atomLinkOut{Href: item.Link},
+			ID:      item.Link,
+			Updated: updated,
+			Source:  item.Source,
+		})
+	}
+	return feed
+}
+
+// writeSummaryAtom writes summary as a merged Atom feed.
+func writeSummaryAtom(w http.ResponseWriter, summary Summary) {
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	if err := xml.NewEncoder(w).Encode(buildAtomFeed(summary)); err != nil {
+		log.Printf("Error encoding summary Atom feed: %v", err)
+	}
+}
+
+// summaryAtomHandler serves /api/summary.atom directly, regardless of Accept.
+func summaryAtomHandler(w http.ResponseWriter, r *http.Request) {
+	summary, err := getLatestSummary(r.Context())
+	if err != nil {
+		log.Printf("Handler warning: serving potentially stale summary Atom feed due to error: %v", err)
+	}
+	writeSummaryAtom(w, summary)
+}
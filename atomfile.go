@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+)
+
+// atomFeedXML is the minimal subset of an Atom feed document we care about.
+type atomFeedXML struct {
+	Entries []atomEntryXML `xml:"entry"`
+}
+
+type atomEntryXML struct {
+	Title     string `xml:"title"`
+	Summary   string `xml:"summary"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+	Links     []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+}
+
+func (e atomEntryXML) link() string {
+	for _, l := range e.Links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			return l.Href
+		}
+	}
+	if len(e.Links) > 0 {
+		return e.Links[0].Href
+	}
+	return ""
+}
+
+// AtomFileSource reads a local Atom XML file from disk, for feeds that are
+// mirrored or generated out-of-band rather than fetched over HTTP.
+type AtomFileSource struct {
+	statusTracker
+	name string
+	path string
+}
+
+// NewAtomFileSource builds a Source that reads Atom entries from a local file.
+func NewAtomFileSource(name, path string) *AtomFileSource {
+	return &AtomFileSource{name: name, path: path}
+}
+
+func (a *AtomFileSource) Title() string { return a.name }
+
+// Link returns the local file path, used as the source's "home" link in the
+// JSON API.
+func (a *AtomFileSource) Link() string { return a.path }
+
+func (a *AtomFileSource) Fetch(ctx context.Context) ([]Item, error) {
+	start := time.Now()
+	data, err := os.ReadFile(a.path)
+	elapsed := time.Since(start)
+	if err != nil {
+		a.record(0, elapsed, err)
+		return nil, fmt.Errorf("atom file %s: %w", a.name, err)
+	}
+
+	var feed atomFeedXML
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		a.record(0, elapsed, err)
+		return nil, fmt.Errorf("atom file %s: %w", a.name, err)
+	}
+	a.record(200, elapsed, nil)
+
+	items := make([]Item, 0, len(feed.Entries))
+	for _, e := range feed.Entries {
+		var published, updated *time.Time
+		if t, err := time.Parse(time.RFC3339, e.Published); err == nil {
+			published = &t
+		}
+		if t, err := time.Parse(time.RFC3339, e.Updated); err == nil {
+			updated = &t
+		}
+		items = append(items, Item{
+			Title:           e.Title,
+			Link:            e.link(),
+			Description:     e.Summary,
+			PublishedParsed: published,
+			UpdatedParsed:   updated,
+			PublishedString: e.Published,
+			SourceName:      a.name,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// cacheDir is where per-feed bodies and metadata are persisted so a
+	// restart doesn't lose ETag/Last-Modified state or force a refetch.
+	cacheDir = "./cache"
+	// maxFeedBodyBytes caps how much of a feed response we'll read, so a
+	// malicious or broken feed can't exhaust memory.
+	maxFeedBodyBytes = 5 * 1024 * 1024 // 5 MB
+)
+
+// cacheMeta is the on-disk meta.json sidecar for a cached feed body.
+type cacheMeta struct {
+	ETag            string    `json:"etag,omitempty"`
+	LastModified    string    `json:"last_modified,omitempty"`
+	FetchedAt       time.Time `json:"fetched_at"`
+	IntervalMinutes int       `json:"interval_minutes"`
+}
+
+// FeedCache persists feed bodies and conditional-GET metadata under a
+// per-feed directory: <dir>/<feedname>/latest.xml and meta.json.
+type FeedCache struct {
+	dir string
+}
+
+// NewFeedCache builds a FeedCache rooted at dir, creating it if needed.
+func NewFeedCache(dir string) *FeedCache {
+	return &FeedCache{dir: dir}
+}
+
+// feedCache is the shared on-disk cache used by every HTTP-backed Source.
+var feedCache = NewFeedCache(cacheDir)
+
+var feedNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+func (c *FeedCache) feedDir(name string) string {
+	safe := feedNameSanitizer.ReplaceAllString(strings.ToLower(name), "-")
+	return filepath.Join(c.dir, safe)
+}
+
+func (c *FeedCache) load(name string) (body []byte, meta cacheMeta, ok bool) {
+	dir := c.feedDir(name)
+	body, err := os.ReadFile(filepath.Join(dir, "latest.xml"))
+	if err != nil {
+		return nil, cacheMeta{}, false
+	}
+	metaBytes, err := os.ReadFile(filepath.Join(dir, "meta.json"))
+	if err != nil {
+		return nil, cacheMeta{}, false
+	}
+	if err := json.Unmarshal(metaBytes, &meta); err != nil {
+		return nil, cacheMeta{}, false
+	}
+	return body, meta, true
+}
+
+func (c *FeedCache) save(name string, body []byte, meta cacheMeta) error {
+	dir := c.feedDir(name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "latest.xml"), body, 0o644); err != nil {
+		return err
+	}
+	metaBytes, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "meta.json"), metaBytes, 0o644)
+}
+
+// refreshInterval returns the configured minutes for name, falling back to
+// fallback when no cache entry exists yet.
+func (c *FeedCache) refreshInterval(name string, fallback time.Duration) time.Duration {
+	_, meta, ok := c.load(name)
+	if !ok || meta.IntervalMinutes <= 0 {
+		return fallback
+	}
+	return time.Duration(meta.IntervalMinutes) * time.Minute
+}
+
+// fetchWithCache returns the cached body for name without touching the
+// network at all if it's not due for a refetch yet per its own interval (see
+// refreshInterval); otherwise it performs a conditional GET, sending
+// If-None-Match / If-Modified-Since from the cached meta.json, reusing the
+// cached body on 304, and otherwise storing the new body and meta back to
+// disk. intervalMinutes is the feed's configured refresh interval, which is
+// persisted for refreshInterval to honor on the next call; it is widened
+// when the server's Expires/Cache-Control: max-age suggest a longer one.
+func fetchWithCache(ctx context.Context, client httpDoer, cache *FeedCache, name, url string, intervalMinutes int) (string, int, error) {
+	cachedBody, meta, hasCache := cache.load(name)
+
+	if hasCache {
+		interval := cache.refreshInterval(name, time.Duration(intervalMinutes)*time.Minute)
+		if time.Since(meta.FetchedAt) < interval {
+			// Not due yet per this feed's own interval (possibly widened by
+			// a prior response's Expires/Cache-Control): reuse the cached
+			// body without a network round-trip at all.
+			return string(cachedBody), http.StatusNotModified, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	if hasCache {
+		if meta.ETag != "" {
+			req.Header.Set("If-None-Match", meta.ETag)
+		}
+		if meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", meta.LastModified)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		if !hasCache {
+			return "", resp.StatusCode, errors.New("304 Not Modified with no cached body")
+		}
+		meta.FetchedAt = time.Now()
+		_ = cache.save(name, cachedBody, meta)
+		return string(cachedBody), resp.StatusCode, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := readAllCapped(resp.Body, maxFeedBodyBytes)
+	if err != nil {
+		return "", resp.StatusCode, fmt.Errorf("reading body (capped at %d bytes): %w", maxFeedBodyBytes, err)
+	}
+
+	newMeta := cacheMeta{
+		ETag:            resp.Header.Get("ETag"),
+		LastModified:    resp.Header.Get("Last-Modified"),
+		FetchedAt:       time.Now(),
+		IntervalMinutes: intervalMinutes,
+	}
+	if mins := freshnessMinutes(resp.Header); mins > newMeta.IntervalMinutes {
+		newMeta.IntervalMinutes = mins
+	}
+	if err := cache.save(name, body, newMeta); err != nil {
+		log.Printf("warning: failed to persist cache for %s: %v", name, err)
+	}
+
+	return string(body), resp.StatusCode, nil
+}
+
+// freshnessMinutes derives a suggested refresh interval from the response's
+// Expires header or Cache-Control: max-age, whichever implies staying
+// fresh longer. Returns 0 if neither is present or parseable.
+func freshnessMinutes(h http.Header) int {
+	if cc := h.Get("Cache-Control"); cc != "" {
+		for _, part := range strings.Split(cc, ",") {
+			part = strings.TrimSpace(part)
+			if strings.HasPrefix(part, "max-age=") {
+				if secs, err := strconv.Atoi(strings.TrimPrefix(part, "max-age=")); err == nil {
+					return secs / 60
+				}
+			}
+		}
+	}
+	if exp := h.Get("Expires"); exp != "" {
+		if t, err := http.ParseTime(exp); err == nil {
+			if mins := int(time.Until(t).Minutes()); mins > 0 {
+				return mins
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// countingDoer counts how many times Do is called, so tests can assert a
+// network round-trip was (or wasn't) made.
+type countingDoer struct {
+	calls int
+	doer  fakeHTTPDoer
+}
+
+func (c *countingDoer) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	return c.doer.Do(req)
+}
+
+func TestFetchWithCacheSkipsNetworkWhenNotDue(t *testing.T) {
+	cache := NewFeedCache(t.TempDir())
+	client := &countingDoer{doer: fakeHTTPDoer{status: http.StatusOK, body: "first"}}
+
+	body, _, err := fetchWithCache(context.Background(), client, cache, "test-feed", "https://example.com/feed", 60)
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if body != "first" {
+		t.Fatalf("first fetch body = %q, want %q", body, "first")
+	}
+	if client.calls != 1 {
+		t.Fatalf("first fetch made %d requests, want 1", client.calls)
+	}
+
+	client.doer.body = "second"
+	body, _, err = fetchWithCache(context.Background(), client, cache, "test-feed", "https://example.com/feed", 60)
+	if err != nil {
+		t.Fatalf("second fetch: %v", err)
+	}
+	if body != "first" {
+		t.Fatalf("second fetch body = %q, want cached %q (should not have refetched)", body, "first")
+	}
+	if client.calls != 1 {
+		t.Fatalf("second fetch made a network call (total %d), want still 1 since the feed isn't due yet", client.calls)
+	}
+}
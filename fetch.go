@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// sourceResult is one Source's outcome from a single fetch pass, shared
+// between the grouped summary renderer and the river-of-news renderer so
+// both work from the same concurrent fetch instead of duplicating it.
+type sourceResult struct {
+	Source Source
+	Items  []Item
+	Err    error
+}
+
+// fetchAllSources fetches every registered Source concurrently, honoring
+// ctx cancellation, and returns one result per source in registry order.
+func fetchAllSources(ctx context.Context) []sourceResult {
+	var wg sync.WaitGroup
+	results := make([]sourceResult, len(sources))
+
+	log.Printf("Fetching %d sources...", len(sources))
+
+	for i, src := range sources {
+		wg.Add(1)
+		go func(i int, src Source) {
+			defer wg.Done()
+
+			fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+			defer cancel()
+
+			items, err := src.Fetch(fetchCtx)
+			if err != nil {
+				log.Printf("Error fetching source %s: %v", src.Title(), err)
+			}
+			results[i] = sourceResult{Source: src, Items: items, Err: err}
+		}(i, src)
+	}
+
+	wg.Wait()
+	log.Println("Finished fetching sources.")
+	return results
+}
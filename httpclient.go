@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// httpDoer is the subset of *http.Client used by Source adapters, so tests
+// can substitute a fake transport without spinning up a real server.
+type httpDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{Timeout: fetchTimeout}
+}
+
+// readAllCapped reads at most maxBytes+1 from r and errors if more was
+// available, so a malicious or broken feed can't exhaust memory. This
+// mirrors the cutoff behavior of http.MaxBytesReader without requiring an
+// http.ResponseWriter, which client-side reads don't have.
+func readAllCapped(r io.Reader, maxBytes int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, fmt.Errorf("response body exceeds %d byte limit", maxBytes)
+	}
+	return body, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// jsonFeedDoc is the minimal subset of the JSON Feed spec (jsonfeed.org) we
+// render: https://www.jsonfeed.org/version/1.1/
+type jsonFeedDoc struct {
+	Title string        `json:"title"`
+	Items []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	Title         string `json:"title"`
+	URL           string `json:"url"`
+	Summary       string `json:"summary"`
+	ContentText   string `json:"content_text"`
+	DatePublished string `json:"date_published"`
+	DateModified  string `json:"date_modified"`
+}
+
+// JSONFeedSource fetches and decodes a JSON Feed endpoint.
+type JSONFeedSource struct {
+	statusTracker
+	name            string
+	url             string
+	client          httpDoer
+	cache           *FeedCache
+	intervalMinutes int
+}
+
+// NewJSONFeedSource builds a Source backed by a JSON Feed URL, refreshed at
+// most every intervalMinutes.
+func NewJSONFeedSource(name, url string, intervalMinutes int) *JSONFeedSource {
+	return &JSONFeedSource{
+		name:            name,
+		url:             url,
+		client:          defaultHTTPClient(),
+		cache:           feedCache,
+		intervalMinutes: intervalMinutes,
+	}
+}
+
+func (j *JSONFeedSource) Title() string { return j.name }
+
+// Link returns the feed URL, used as the source's "home" link in the JSON API.
+func (j *JSONFeedSource) Link() string { return j.url }
+
+func (j *JSONFeedSource) Fetch(ctx context.Context) ([]Item, error) {
+	start := time.Now()
+	body, status, err := withRetry(ctx, defaultFetchPolicy, func() (string, int, error) {
+		return fetchWithCache(ctx, j.client, j.cache, j.name, j.url, j.intervalMinutes)
+	})
+	if err != nil {
+		j.record(status, time.Since(start), err)
+		return nil, fmt.Errorf("json feed %s: %w", j.name, err)
+	}
+
+	var doc jsonFeedDoc
+	if err := json.Unmarshal([]byte(body), &doc); err != nil {
+		j.record(status, time.Since(start), err)
+		return nil, fmt.Errorf("json feed %s: %w", j.name, err)
+	}
+	j.record(status, time.Since(start), nil)
+
+	items := make([]Item, 0, len(doc.Items))
+	for _, fi := range doc.Items {
+		var published, updated *time.Time
+		if t, err := time.Parse(time.RFC3339, fi.DatePublished); err == nil {
+			published = &t
+		}
+		if t, err := time.Parse(time.RFC3339, fi.DateModified); err == nil {
+			updated = &t
+		}
+		desc := fi.Summary
+		if desc == "" {
+			desc = fi.ContentText
+		}
+		items = append(items, Item{
+			Title:           fi.Title,
+			Link:            fi.URL,
+			Description:     desc,
+			PublishedParsed: published,
+			UpdatedParsed:   updated,
+			PublishedString: fi.DatePublished,
+			SourceName:      j.name,
+		})
+	}
+	return items, nil
+}
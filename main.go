@@ -1,160 +1,91 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
-
-	"github.com/gomarkdown/markdown"
-	mdhtml "github.com/gomarkdown/markdown/html"
-	"github.com/gomarkdown/markdown/parser"
-	"github.com/mmcdole/gofeed"
 )
 
 // Constants
 const (
 	maxItemsPerFeed = 3               // Number of items to display per feed
-	cacheTTL        = 1 * time.Hour   // How long to cache the summary
+	cacheTTL        = 5 * time.Minute // How often to re-run the fetch pass; each Source's own refresh interval (see cache.go) still governs whether that pass actually hits the network or reuses its on-disk cache
 	fetchTimeout    = 10 * time.Second // Timeout for fetching each feed
 	serverPort      = ":8080"         // Port for the HTTP server
 )
 
+// itemDescriptionMode controls whether item descriptions are rendered
+// alongside the title/link, and how (see sanitize.go). Off by default:
+// flip to DescriptionTextOnly or DescriptionSafeHTML to show them.
+const itemDescriptionMode = DescriptionOff
+
 // Global variables for caching the news summary
 var (
-	cachedSummaryMD       string       // Cached summary in Markdown format
-	cachedSummaryHTML     string       // Cached summary pre-rendered to HTML
+	cachedSummary         Summary      // Cached, typed fetch result shared by every renderer
 	lastSummaryUpdateTime time.Time
 	summaryMutex          sync.RWMutex // Read-write mutex for safe concurrent access
 	summaryFetchError     error        // Store potential error during fetch
 )
 
-// Define the RSS feeds to fetch
-var rssFeeds = []struct {
-	Name string
-	URL  string
-}{
-	{"BBC News", "http://feeds.bbci.co.uk/news/world/rss.xml"},
-	// {"TechCrunch", "http://feeds.feedburner.com/TechCrunch/"}, // Removed TechCrunch
-	{"The Guardian", "https://www.theguardian.com/world/rss"},
-	{"NPR News", "https://feeds.npr.org/1001/rss.xml"},
-	{"Al Jazeera", "http://www.aljazeera.com/xml/rss/all.xml"},
+// sources is the registry of everywhere we pull headlines from. Each entry
+// is a Source, so RSS feeds, Mastodon timelines, local Atom files, and JSON
+// Feed endpoints can all sit side by side.
+var sources = []Source{
+	NewRSSSource("BBC News", "http://feeds.bbci.co.uk/news/world/rss.xml", 30),
+	// NewRSSSource("TechCrunch", "http://feeds.feedburner.com/TechCrunch/", defaultRefreshMinutes), // Removed TechCrunch
+	NewRSSSource("The Guardian", "https://www.theguardian.com/world/rss", 30),
+	NewRSSSource("NPR News", "https://feeds.npr.org/1001/rss.xml", 30),
+	NewRSSSource("Al Jazeera", "http://www.aljazeera.com/xml/rss/all.xml", defaultRefreshMinutes),
+	NewMastodonSource("Mastodon Social (@Mastodon)", "https://mastodon.social/@Mastodon", 30),
+	NewAtomFileSource("Local Press Releases", "./feeds/press-releases.atom.xml"),
+	NewJSONFeedSource("JSON Feed", "https://www.jsonfeed.org/feed.json", defaultRefreshMinutes),
 }
 
-// fetchAndSummarizeNews fetches news from multiple RSS feeds concurrently
-// and returns the combined summary in Markdown format.
-func fetchAndSummarizeNews() (string, error) {
-	// Configure HTTP client with timeout
-	httpClient := &http.Client{Timeout: fetchTimeout}
-	fp := gofeed.NewParser()
-	fp.Client = httpClient // Assign the client to the parser
-
-	var wg sync.WaitGroup
-	var resultsMutex sync.Mutex
-	results := make(map[string]string) // Store results keyed by feed name
-	fetchErrors := []string{}          // Collect errors
-
-	log.Printf("Fetching %d RSS feeds...", len(rssFeeds))
-
-	for _, feedSource := range rssFeeds {
-		wg.Add(1)
-		go func(name, url string) {
-			defer wg.Done()
-			// Use context-aware parsing if needed, for now ParseURL with timeout client is sufficient
-			feed, err := fp.ParseURL(url)
-			if err != nil {
-				log.Printf("Error fetching feed %s (%s): %v", name, url, err) // Use %v for errors
-				resultsMutex.Lock()
-				fetchErrors = append(fetchErrors, fmt.Sprintf("Failed to fetch %s: %v", name, err)) // Use %v
-				resultsMutex.Unlock()
-				return
-			}
-
-			var feedContent strings.Builder
-			feedContent.WriteString(fmt.Sprintf("## %s\n\n", feed.Title)) // Use feed title from RSS
-
-			count := 0
-			for _, item := range feed.Items {
-				if count >= maxItemsPerFeed {
-					break
-				}
-				// Basic formatting: Title as link (if available)
-				feedContent.WriteString(fmt.Sprintf("*   [%s](%s)\n", item.Title, item.Link))
-				// Optionally add description:
-				// feedContent.WriteString(fmt.Sprintf("    *Description:* %s\n", item.Description)) // Be mindful of HTML in descriptions
-				count++
-			}
-			feedContent.WriteString("\n") // Add space after each feed section
-
-			resultsMutex.Lock()
-			results[name] = feedContent.String() // Store by original name for consistent ordering if needed
-			resultsMutex.Unlock()
-		}(feedSource.Name, feedSource.URL)
-	}
-
-	wg.Wait()
-	log.Println("Finished fetching RSS feeds.")
-
-	// Combine results - iterate through original list to maintain order
-	var finalSummary strings.Builder
-	for _, feedSource := range rssFeeds {
-		if content, ok := results[feedSource.Name]; ok {
-			finalSummary.WriteString(content)
-		}
+// sourcesAllFailed reports whether every source in a Summary came back with
+// an error, which getLatestSummary treats as a total fetch failure.
+func sourcesAllFailed(summary Summary) bool {
+	if len(summary.Sources) == 0 {
+		return false
 	}
-
-	// Report any errors at the end
-	if len(fetchErrors) > 0 {
-		finalSummary.WriteString("\n---\n**Errors during fetch:**\n")
-		for _, errMsg := range fetchErrors {
-			finalSummary.WriteString(fmt.Sprintf("*   %s\n", errMsg))
+	for _, src := range summary.Sources {
+		if src.Error == "" {
+			return false
 		}
 	}
-
-	if finalSummary.Len() == 0 && len(fetchErrors) > 0 {
-		// If all feeds failed
-		return "", fmt.Errorf("failed to fetch any RSS feeds")
-	}
-
-	return finalSummary.String(), nil // Return combined summary, error is handled within the summary string or if all fail
+	return true
 }
 
-// getLatestNewsSummary returns the cached summary (Markdown and HTML) if it's recent,
-// otherwise triggers a new fetch.
-func getLatestNewsSummary() (string, string, error) {
+// getLatestSummary returns the cached Summary if it's recent, otherwise
+// triggers a new fetch pass. The triggering request's ctx is deliberately
+// not threaded into that fetch pass (see below): it populates a cache
+// shared by every concurrent caller, not just this one, so it must keep
+// running even if this particular caller disconnects. Every renderer
+// (grouped HTML, river HTML, JSON, Atom) builds its output from this one
+// cached Summary.
+func getLatestSummary(_ context.Context) (Summary, error) {
 	summaryMutex.RLock() // Acquire read lock to check time
-	// Use >= cacheTTL for comparison
-	needsUpdate := time.Since(lastSummaryUpdateTime) >= cacheTTL || cachedSummaryMD == ""
+	needsUpdate := time.Since(lastSummaryUpdateTime) >= cacheTTL || len(cachedSummary.Sources) == 0
 	summaryMutex.RUnlock() // Release read lock
 
 	if needsUpdate {
 		summaryMutex.Lock() // Acquire write lock for potential update
 		// Double-check if another goroutine updated it while waiting for the lock
-		if time.Since(lastSummaryUpdateTime) >= cacheTTL || cachedSummaryMD == "" {
+		if time.Since(lastSummaryUpdateTime) >= cacheTTL || len(cachedSummary.Sources) == 0 {
 			log.Println("News summary cache expired or empty. Fetching new summary...")
-			summaryMD, err := fetchAndSummarizeNews()
-			if err != nil {
-				log.Printf("Error fetching news summary: %v", err)
+			// Deliberately context.Background(), not ctx: this fetch pass
+			// populates the cache for every concurrent caller, not just the
+			// one that happened to trigger it, so it must not be canceled
+			// by that caller's connection going away.
+			summary := buildSummary(context.Background())
+			if sourcesAllFailed(summary) {
+				summaryFetchError = fmt.Errorf("failed to fetch any sources")
+				log.Printf("Error fetching news summary: %v", summaryFetchError)
 				// Keep the stale cache but store the error
-				summaryFetchError = err
-				// Optionally, clear the cache on error:
-				// cachedSummaryMD = ""
-				// cachedSummaryHTML = ""
 			} else {
-				// Convert Markdown to HTML here, only on successful fetch
-				extensions := parser.CommonExtensions | parser.AutoHeadingIDs
-				p := parser.NewWithExtensions(extensions)
-				doc := p.Parse([]byte(summaryMD))
-				htmlFlags := mdhtml.CommonFlags | mdhtml.HrefTargetBlank
-				opts := mdhtml.RendererOptions{Flags: htmlFlags}
-				renderer := mdhtml.NewRenderer(opts)
-				summaryHTML := string(markdown.Render(doc, renderer))
-
-				// Update cache
-				cachedSummaryMD = summaryMD
-				cachedSummaryHTML = summaryHTML
+				cachedSummary = summary
 				summaryFetchError = nil // Clear previous error on success
 			}
 			lastSummaryUpdateTime = time.Now() // Update time even if fetch failed to prevent constant retries
@@ -165,31 +96,47 @@ func getLatestNewsSummary() (string, string, error) {
 	// Return the current cache content and any stored error
 	summaryMutex.RLock()
 	defer summaryMutex.RUnlock()
-	// If there was an error during the last fetch attempt, return it along with potentially stale data
 	if summaryFetchError != nil {
 		// Return stale data but also the error
-		return cachedSummaryMD, cachedSummaryHTML, summaryFetchError
+		return cachedSummary, summaryFetchError
 	}
-	// Return fresh (or acceptably old) data
-	return cachedSummaryMD, cachedSummaryHTML, nil
+	return cachedSummary, nil
+}
+
+// summarySnapshot returns the currently cached Summary and when it was
+// fetched, without triggering a new fetch pass - used by /metrics and
+// /healthz, which should report the current state rather than cause work.
+func summarySnapshot() (Summary, time.Time) {
+	summaryMutex.RLock()
+	defer summaryMutex.RUnlock()
+	return cachedSummary, lastSummaryUpdateTime
 }
 
-// timeHandler writes an HTML page with the current time, news summary.
+// timeHandler serves the news summary at "/", negotiating HTML, JSON, or
+// Atom based on the request's Accept header - all rendered from the same
+// cached Summary (see summary.go and api.go).
 func timeHandler(w http.ResponseWriter, r *http.Request) {
+	summary, err := getLatestSummary(r.Context())
+	if err != nil {
+		log.Printf("Handler warning: serving potentially stale news summary due to error: %v", err)
+	}
+
+	switch negotiateFormat(r) {
+	case formatJSON:
+		writeSummaryJSON(w, summary)
+		return
+	case formatAtom:
+		writeSummaryAtom(w, summary)
+		return
+	}
+
 	// Format the time as "Friday 18 April at 15:41"
 	// Go's reference time: Mon Jan 2 15:04:05 MST 2006
 	currentTime := time.Now().Format("Monday 02 January at 15:04")
 
-	// Get the latest news summary (from cache or fetch)
-	_, summaryHTML, err := getLatestNewsSummary() // We only need HTML for display
-	if err != nil {
-		// Log the error that occurred during the fetch/cache retrieval
-		log.Printf("Handler warning: serving potentially stale news summary due to error: %v", err)
-		// We still proceed to show potentially stale content, but the error is logged.
-		// If summaryHTML is empty (e.g., first run failed), we might want to display an error message.
-		if summaryHTML == "" {
-			summaryHTML = "<p><em>Could not retrieve news summary. Please try again later.</em></p>"
-		}
+	summaryHTML := renderMarkdownToHTML(renderSummaryMarkdown(summary))
+	if summaryHTML == "" {
+		summaryHTML = "<p><em>Could not retrieve news summary. Please try again later.</em></p>"
 	}
 
 	// Set headers
@@ -307,11 +254,18 @@ func timeHandler(w http.ResponseWriter, r *http.Request) {
 func main() {
 	// Initial fetch of summary on startup (optional, can be blocking)
 	// log.Println("Performing initial news summary fetch...")
-	// getLatestNewsSummary() // Call once to populate cache initially
+	// getLatestSummary(context.Background()) // Call once to populate cache initially
 
-	// Register the timeHandler function for the root path.
 	// Register the timeHandler function for the root path.
 	http.HandleFunc("/", timeHandler)
+	// Register the merged, chronologically sorted river-of-news view.
+	http.HandleFunc("/river", riverHandler)
+	// Register the structured API endpoints (see api.go).
+	http.HandleFunc("/api/summary.json", summaryJSONHandler)
+	http.HandleFunc("/api/summary.atom", summaryAtomHandler)
+	// Register Prometheus metrics and the liveness/health check (see metrics.go).
+	http.HandleFunc("/metrics", metricsHandler)
+	http.HandleFunc("/healthz", healthzHandler)
 
 	// Use the constant for the port
 	log.Printf("Server starting on port %s\n", serverPort)
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/gomarkdown/markdown"
+	mdhtml "github.com/gomarkdown/markdown/html"
+	"github.com/gomarkdown/markdown/parser"
+)
+
+// renderMarkdownToHTML renders Markdown (as produced by renderSummaryMarkdown
+// and renderRiverMarkdown) to HTML using the same extensions and flags
+// everywhere, so every view looks consistent.
+func renderMarkdownToHTML(md string) string {
+	extensions := parser.CommonExtensions | parser.AutoHeadingIDs
+	p := parser.NewWithExtensions(extensions)
+	doc := p.Parse([]byte(md))
+	htmlFlags := mdhtml.CommonFlags | mdhtml.HrefTargetBlank
+	opts := mdhtml.RendererOptions{Flags: htmlFlags}
+	renderer := mdhtml.NewRenderer(opts)
+	return string(markdown.Render(doc, renderer))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// writeMetricHelp writes the HELP/TYPE preamble for a Prometheus metric.
+func writeMetricHelp(sb *strings.Builder, name, help, metricType string) {
+	fmt.Fprintf(sb, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(sb, "# TYPE %s %s\n", name, metricType)
+}
+
+// metricsHandler serves /metrics in Prometheus text exposition format,
+// derived from each Source's own health tracking (see sources.go) plus the
+// cached Summary's per-source item counts.
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	summary, lastFetch := summarySnapshot()
+	itemCounts := make(map[string]int, len(summary.Sources))
+	for _, src := range summary.Sources {
+		itemCounts[src.Name] = len(src.Items)
+	}
+
+	var sb strings.Builder
+
+	writeMetricHelp(&sb, "feed_fetch_total", "Total fetch attempts per source, by outcome, since the process started", "counter")
+	writeMetricHelp(&sb, "feed_fetch_duration_seconds", "Duration of the most recent fetch attempt", "gauge")
+	writeMetricHelp(&sb, "feed_items_returned", "Number of items returned by the most recent successful fetch", "gauge")
+	writeMetricHelp(&sb, "feed_last_success_timestamp", "Unix timestamp of the most recent successful fetch", "gauge")
+	for _, src := range sources {
+		name := src.Title()
+		status := src.LastStatus()
+
+		fmt.Fprintf(&sb, "feed_fetch_total{source=%q,status=\"success\"} %d\n", name, status.SuccessCount)
+		fmt.Fprintf(&sb, "feed_fetch_total{source=%q,status=\"error\"} %d\n", name, status.ErrorCount)
+
+		fmt.Fprintf(&sb, "feed_fetch_duration_seconds{source=%q} %f\n", name, status.ResponseTime.Seconds())
+		fmt.Fprintf(&sb, "feed_items_returned{source=%q} %d\n", name, itemCounts[name])
+		if !status.LastSuccess.IsZero() {
+			fmt.Fprintf(&sb, "feed_last_success_timestamp{source=%q} %d\n", name, status.LastSuccess.Unix())
+		}
+	}
+
+	writeMetricHelp(&sb, "summary_cache_age_seconds", "Seconds since the aggregated summary was last refreshed", "gauge")
+	fmt.Fprintf(&sb, "summary_cache_age_seconds %f\n", time.Since(lastFetch).Seconds())
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(sb.String()))
+}
+
+// healthzHandler serves /healthz, returning 200 only when at least one
+// source has succeeded within the last 2*cacheTTL, and 503 otherwise -
+// enough for an operator to alert when a feed silently rots.
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	staleAfter := 2 * cacheTTL
+	now := time.Now()
+
+	for _, src := range sources {
+		lastSuccess := src.LastStatus().LastSuccess
+		if !lastSuccess.IsZero() && now.Sub(lastSuccess) < staleAfter {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintln(w, "ok")
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusServiceUnavailable)
+	fmt.Fprintln(w, "no source has succeeded recently")
+}
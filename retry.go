@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"time"
+)
+
+// FetchPolicy controls how a Source retries a transient fetch failure
+// (network error, 5xx, or 429) before giving up, so one flaky feed backs
+// off instead of either hammering the origin or stalling the whole batch.
+type FetchPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Jitter         time.Duration
+}
+
+// defaultFetchPolicy is used by every Source unless it needs something more
+// aggressive or conservative.
+var defaultFetchPolicy = FetchPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     4 * time.Second,
+	Jitter:         150 * time.Millisecond,
+}
+
+// backoff returns the delay before retry attempt n (0-indexed), doubling
+// InitialBackoff each attempt up to MaxBackoff and adding up to Jitter of
+// random slack so many feeds retrying at once don't thunder together.
+func (p FetchPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if delay > p.MaxBackoff {
+			delay = p.MaxBackoff
+			break
+		}
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(p.Jitter)))
+	}
+	return delay
+}
+
+// isRetryableStatus reports whether an HTTP status is worth retrying:
+// server errors and rate limiting, but not client errors like 404 or 410.
+func isRetryableStatus(status int) bool {
+	return status >= 500 || status == 429
+}
+
+// isRetryableError reports whether err looks like a transient network
+// failure rather than something retrying won't fix (e.g. a parse error).
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// withRetry runs fetch up to policy.MaxRetries+1 times, backing off between
+// attempts, and stops early if ctx is canceled. fetch returns the body, the
+// HTTP status observed (0 if none), and an error.
+func withRetry(ctx context.Context, policy FetchPolicy, fetch func() (string, int, error)) (string, int, error) {
+	var (
+		body   string
+		status int
+		err    error
+	)
+	for attempt := 0; ; attempt++ {
+		body, status, err = fetch()
+		if err == nil {
+			return body, status, nil
+		}
+		if attempt >= policy.MaxRetries || !(isRetryableStatus(status) || isRetryableError(err)) {
+			return body, status, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return body, status, ctx.Err()
+		case <-time.After(policy.backoff(attempt)):
+		}
+	}
+}
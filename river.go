@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// riverCap bounds how many items the merged river view renders, so a quiet
+// page load doesn't turn into hundreds of headlines.
+const riverCap = 50
+
+// riverTimeLayouts are tried in order when an item has no parsed timestamp
+// and we have to fall back to its raw PublishedString. RFC1123 is listed
+// first since it's what most RSS feeds emit, but feeds are inconsistent.
+var riverTimeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"2006-01-02T15:04:05Z07:00",
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+}
+
+// itemTime resolves the best available timestamp for an item: PublishedParsed,
+// then UpdatedParsed, then a best-effort parse of PublishedString. Returns
+// the zero Time if none of these yield a usable timestamp, so the item
+// sorts last rather than crashing the comparison.
+//
+// This exists because naively sorting by PublishedString would sort RFC1123
+// strings lexicographically ("Fri, 02 Jan" vs "Mon, 01 Dec") instead of
+// chronologically - everything must go through time.Time first.
+func itemTime(item Item) time.Time {
+	if item.PublishedParsed != nil {
+		return *item.PublishedParsed
+	}
+	if item.UpdatedParsed != nil {
+		return *item.UpdatedParsed
+	}
+	for _, layout := range riverTimeLayouts {
+		if t, err := time.Parse(layout, item.PublishedString); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// riverHandler serves the merged, chronologically sorted view of every
+// source's headlines at /river.
+func riverHandler(w http.ResponseWriter, r *http.Request) {
+	summary, err := getLatestSummary(r.Context())
+	if err != nil {
+		log.Printf("Handler warning: serving potentially stale river due to error: %v", err)
+	}
+	riverHTML := renderMarkdownToHTML(renderRiverMarkdown(summary))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, `<!DOCTYPE html>
+<html>
+<head><title>River of News</title></head>
+<body>
+<div>%s</div>
+</body>
+</html>
+`, riverHTML)
+}
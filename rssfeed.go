@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// defaultRefreshMinutes is used for feeds that don't specify their own
+// refresh interval, mirroring the feedinfo (URL + minutes) pattern.
+const defaultRefreshMinutes = 60
+
+// RSSSource wraps gofeed so existing RSS feeds keep working unchanged under
+// the new Source interface, now reading through the shared FeedCache so
+// repeated fetches send conditional GETs instead of always re-downloading.
+type RSSSource struct {
+	statusTracker
+	name            string
+	url             string
+	parser          *gofeed.Parser
+	client          httpDoer
+	cache           *FeedCache
+	intervalMinutes int
+}
+
+// NewRSSSource builds a Source for a standard RSS/Atom feed URL, refreshed
+// at most every intervalMinutes (subject to the server's own Expires/
+// Cache-Control suggestion, if longer).
+func NewRSSSource(name, url string, intervalMinutes int) *RSSSource {
+	fp := gofeed.NewParser()
+	client := defaultHTTPClient()
+	return &RSSSource{
+		name:            name,
+		url:             url,
+		parser:          fp,
+		client:          client,
+		cache:           feedCache,
+		intervalMinutes: intervalMinutes,
+	}
+}
+
+func (r *RSSSource) Title() string { return r.name }
+
+// Link returns the feed URL, used as the source's "home" link in the JSON API.
+func (r *RSSSource) Link() string { return r.url }
+
+func (r *RSSSource) Fetch(ctx context.Context) ([]Item, error) {
+	start := time.Now()
+	body, status, err := withRetry(ctx, defaultFetchPolicy, func() (string, int, error) {
+		return fetchWithCache(ctx, r.client, r.cache, r.name, r.url, r.intervalMinutes)
+	})
+	elapsed := time.Since(start)
+	if err != nil {
+		r.record(status, elapsed, err)
+		return nil, fmt.Errorf("rss %s: %w", r.name, err)
+	}
+
+	feed, err := r.parser.Parse(strings.NewReader(body))
+	r.record(status, elapsed, err)
+	if err != nil {
+		return nil, fmt.Errorf("rss %s: %w", r.name, err)
+	}
+
+	items := make([]Item, 0, len(feed.Items))
+	for _, fi := range feed.Items {
+		items = append(items, Item{
+			Title:           fi.Title,
+			Link:            fi.Link,
+			Description:     fi.Description,
+			PublishedParsed: fi.PublishedParsed,
+			UpdatedParsed:   fi.UpdatedParsed,
+			PublishedString: fi.Published,
+			SourceName:      r.name,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// DescriptionMode controls whether and how an item's Description/Content is
+// rendered. Feed HTML can't be trusted, so anything beyond DescriptionOff
+// goes through sanitizeHTML/extractText below before it ever reaches the
+// Markdown renderer.
+type DescriptionMode int
+
+const (
+	// DescriptionOff renders only the title/link, as before.
+	DescriptionOff DescriptionMode = iota
+	// DescriptionTextOnly strips all markup and renders plain text.
+	DescriptionTextOnly
+	// DescriptionSafeHTML keeps a small allowlist of formatting tags.
+	DescriptionSafeHTML
+)
+
+// safeTags is the allowlist used by DescriptionSafeHTML. Everything else is
+// unwrapped (its text kept, its tags dropped); script/style are dropped
+// entirely, including their contents.
+var safeTags = map[atom.Atom]bool{
+	atom.A:      true,
+	atom.P:      true,
+	atom.Em:     true,
+	atom.Strong: true,
+	atom.Ul:     true,
+	atom.Li:     true,
+	atom.Br:     true,
+}
+
+// droppedSubtrees are tags whose entire subtree (including text) is
+// discarded rather than unwrapped - rendering their contents as text would
+// leak raw CSS/JS into the page.
+var droppedSubtrees = map[atom.Atom]bool{
+	atom.Script: true,
+	atom.Style:  true,
+	atom.Iframe: true,
+}
+
+// renderDescription renders desc according to mode, returning "" for
+// DescriptionOff or if desc is empty.
+func renderDescription(desc string, mode DescriptionMode) string {
+	if desc == "" || mode == DescriptionOff {
+		return ""
+	}
+	switch mode {
+	case DescriptionTextOnly:
+		return extractText(desc)
+	case DescriptionSafeHTML:
+		return sanitizeHTML(desc)
+	default:
+		return ""
+	}
+}
+
+// extractText walks parsed HTML and returns its plain text content, skipping
+// script/style (and other dropped) subtrees entirely so their contents never
+// leak into the output.
+func extractText(rawHTML string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(rawHTML), bodyContext)
+	if err != nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, n := range nodes {
+		walkText(n, &sb)
+	}
+	return collapseSpaces(strings.TrimSpace(sb.String()))
+}
+
+func walkText(n *html.Node, sb *strings.Builder) {
+	if n.Type == html.ElementNode && droppedSubtrees[n.DataAtom] {
+		return
+	}
+	if n.Type == html.TextNode {
+		sb.WriteString(n.Data)
+		sb.WriteString(" ")
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkText(c, sb)
+	}
+}
+
+// sanitizeHTML walks parsed HTML and re-serializes it keeping only the
+// safeTags allowlist (with their "href" attribute when present, for <a>,
+// and no others - so event handlers like onclick never survive), unwrapping
+// everything else, and dropping script/style/iframe subtrees entirely.
+func sanitizeHTML(rawHTML string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(rawHTML), bodyContext)
+	if err != nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, n := range nodes {
+		writeSanitized(n, &sb)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func writeSanitized(n *html.Node, sb *strings.Builder) {
+	if n.Type == html.ElementNode && droppedSubtrees[n.DataAtom] {
+		return
+	}
+
+	allowed := n.Type == html.ElementNode && safeTags[n.DataAtom]
+	if allowed {
+		sb.WriteString("<")
+		sb.WriteString(n.Data)
+		if n.DataAtom == atom.A {
+			if href := safeHref(n); href != "" {
+				sb.WriteString(` href="`)
+				sb.WriteString(html.EscapeString(href))
+				sb.WriteString(`"`)
+			}
+		}
+		sb.WriteString(">")
+	}
+	if n.Type == html.TextNode {
+		sb.WriteString(html.EscapeString(n.Data))
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeSanitized(c, sb)
+	}
+
+	if allowed && n.DataAtom != atom.Br {
+		sb.WriteString("</")
+		sb.WriteString(n.Data)
+		sb.WriteString(">")
+	}
+}
+
+// safeHref returns n's href attribute if it's present and not a javascript:
+// URI, otherwise "".
+func safeHref(n *html.Node) string {
+	for _, attr := range n.Attr {
+		if attr.Key != "href" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(attr.Val)), "javascript:") {
+			return ""
+		}
+		return attr.Val
+	}
+	return ""
+}
+
+// bodyContext is the parse context used for fragment parsing: feed
+// descriptions are HTML body content, never a full document.
+var bodyContext = &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+
+// sanitizeItemTitle runs an item title through extractText before it's
+// interpolated into Markdown, so a title like "<script>...</script>" (feeds
+// are untrusted input, same as descriptions) can't smuggle raw HTML into the
+// rendered page. Unlike Description, titles render unconditionally rather
+// than being gated by itemDescriptionMode, so this can't be skipped.
+func sanitizeItemTitle(title string) string {
+	return extractText(title)
+}
+
+// sanitizeItemLink rejects a javascript: URI outright (mirroring safeHref),
+// otherwise returns link unchanged. Markdown's link target isn't otherwise
+// escaped by the renderer, so this is the only thing standing between a
+// malicious feed and a clickable javascript: link.
+func sanitizeItemLink(link string) string {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(link)), "javascript:") {
+		return ""
+	}
+	return link
+}
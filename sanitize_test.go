@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+func TestExtractTextDropsScriptSubtree(t *testing.T) {
+	got := extractText(`hello <script>alert(1)</script> world`)
+	want := "hello world"
+	if got != want {
+		t.Errorf("extractText = %q, want %q", got, want)
+	}
+}
+
+func TestSanitizeHTMLStripsUnknownTagsAndEventHandlers(t *testing.T) {
+	got := sanitizeHTML(`<p onclick="evil()">safe <b>bold</b> text</p>`)
+	want := `<p>safe bold text</p>`
+	if got != want {
+		t.Errorf("sanitizeHTML = %q, want %q", got, want)
+	}
+}
+
+func TestSanitizeHTMLRejectsJavascriptHref(t *testing.T) {
+	got := sanitizeHTML(`<a href="javascript:alert(1)">click</a>`)
+	want := `<a>click</a>`
+	if got != want {
+		t.Errorf("sanitizeHTML = %q, want %q", got, want)
+	}
+}
+
+// TestSanitizeItemTitleNeutralizesScriptTag guards the always-on item title
+// path (unlike Description, titles aren't gated by itemDescriptionMode): a
+// feed item titled with a script tag must not survive into rendered output.
+func TestSanitizeItemTitleNeutralizesScriptTag(t *testing.T) {
+	got := sanitizeItemTitle("<script>alert(1)</script>")
+	if got != "" {
+		t.Errorf("sanitizeItemTitle = %q, want empty (script subtree dropped)", got)
+	}
+}
+
+func TestSanitizeItemLinkRejectsJavascriptURI(t *testing.T) {
+	got := sanitizeItemLink("javascript:alert(1)")
+	if got != "" {
+		t.Errorf("sanitizeItemLink = %q, want empty", got)
+	}
+	if got := sanitizeItemLink("https://example.com/a"); got != "https://example.com/a" {
+		t.Errorf("sanitizeItemLink changed a safe link: %q", got)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// statusWindow is how many recent fetch attempts each Source remembers when
+// computing its rolling success rate.
+const statusWindow = 10
+
+// Item is a normalized feed entry, shared across every Source implementation
+// so that RSS, Mastodon, Atom-file, and JSON Feed items can be rendered and
+// merged interchangeably.
+type Item struct {
+	Title           string
+	Link            string
+	Description     string
+	PublishedParsed *time.Time
+	UpdatedParsed   *time.Time
+	PublishedString string
+	SourceName      string
+}
+
+// SourceStatus captures the health of a Source's most recent fetch attempts,
+// so the rendered page (and later /metrics) can show per-source health next
+// to the headlines.
+type SourceStatus struct {
+	LastAttempt  time.Time
+	LastSuccess  time.Time
+	LastError    error
+	HTTPStatus   int
+	ResponseTime time.Duration
+	Recent       []bool // true = success, oldest first, capped at statusWindow; used for SuccessRate
+	SuccessCount uint64 // lifetime total successes, never reset or aged out - safe for a Prometheus counter
+	ErrorCount   uint64 // lifetime total errors, never reset or aged out - safe for a Prometheus counter
+}
+
+// SuccessRate returns the fraction of the last N attempts that succeeded.
+func (s SourceStatus) SuccessRate() float64 {
+	if len(s.Recent) == 0 {
+		return 0
+	}
+	ok := 0
+	for _, v := range s.Recent {
+		if v {
+			ok++
+		}
+	}
+	return float64(ok) / float64(len(s.Recent))
+}
+
+// Source is anything that can be fetched for headlines: an RSS feed, a
+// Mastodon public timeline, a local Atom file, or a JSON Feed endpoint.
+type Source interface {
+	// Title is the human-readable name shown above the source's headlines.
+	Title() string
+	// Fetch retrieves the latest items, honoring ctx cancellation.
+	Fetch(ctx context.Context) ([]Item, error)
+	// Error returns the error from the most recent Fetch, or nil.
+	Error() error
+	// LastStatus reports the current health snapshot for this Source.
+	LastStatus() SourceStatus
+}
+
+// statusTracker is embedded by Source implementations to record health
+// without duplicating the bookkeeping in every adapter.
+type statusTracker struct {
+	mu     sync.Mutex
+	status SourceStatus
+	err    error
+}
+
+func (t *statusTracker) record(httpStatus int, elapsed time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.status.LastAttempt = time.Now()
+	t.status.HTTPStatus = httpStatus
+	t.status.ResponseTime = elapsed
+	t.err = err
+
+	success := err == nil
+	if success {
+		t.status.LastSuccess = t.status.LastAttempt
+		t.status.SuccessCount++
+	} else {
+		t.status.ErrorCount++
+	}
+	t.status.Recent = append(t.status.Recent, success)
+	if len(t.status.Recent) > statusWindow {
+		t.status.Recent = t.status.Recent[len(t.status.Recent)-statusWindow:]
+	}
+}
+
+func (t *statusTracker) Error() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+func (t *statusTracker) LastStatus() SourceStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
+
+// mastodonArticleRe pulls individual <article>...</article> blocks out of a
+// Mastodon public timeline page. Mastodon's public HTML doesn't expose a
+// machine-friendly feed, so we scrape the same markup a browser would see.
+var mastodonArticleRe = regexp.MustCompile(`(?s)<article[^>]*class="[^"]*status[^"]*"[^>]*>(.*?)</article>`)
+
+// mastodonBoostRe flags boosted/reblogged statuses so callers can decide
+// whether to surface them alongside original posts.
+var mastodonBoostRe = regexp.MustCompile(`(?s)class="[^"]*status__prepend[^"]*"`)
+
+var (
+	mastodonLinkRe    = regexp.MustCompile(`(?s)<a[^>]*href="([^"]+)"[^>]*class="[^"]*status__relative-time[^"]*"`)
+	mastodonContentRe = regexp.MustCompile(`(?s)<div[^>]*class="[^"]*status__content[^"]*"[^>]*>(.*?)</div>`)
+	htmlTagRe         = regexp.MustCompile(`<[^>]+>`)
+)
+
+// MastodonSource fetches a Mastodon instance's public HTML timeline (e.g.
+// https://mastodon.social/@user or a hashtag/public page) and scrapes each
+// <article> status into an Item.
+type MastodonSource struct {
+	statusTracker
+	name            string
+	url             string
+	client          httpDoer
+	cache           *FeedCache
+	intervalMinutes int
+}
+
+// NewMastodonSource builds a Source backed by a Mastodon public timeline
+// URL, refreshed at most every intervalMinutes.
+func NewMastodonSource(name, url string, intervalMinutes int) *MastodonSource {
+	return &MastodonSource{
+		name:            name,
+		url:             url,
+		client:          defaultHTTPClient(),
+		cache:           feedCache,
+		intervalMinutes: intervalMinutes,
+	}
+}
+
+func (m *MastodonSource) Title() string { return m.name }
+
+// Link returns the Mastodon timeline URL, used as the source's "home" link
+// in the JSON API.
+func (m *MastodonSource) Link() string { return m.url }
+
+func (m *MastodonSource) Fetch(ctx context.Context) ([]Item, error) {
+	start := time.Now()
+	body, status, err := withRetry(ctx, defaultFetchPolicy, func() (string, int, error) {
+		return fetchWithCache(ctx, m.client, m.cache, m.name, m.url, m.intervalMinutes)
+	})
+	m.record(status, time.Since(start), err)
+	if err != nil {
+		return nil, fmt.Errorf("mastodon %s: %w", m.name, err)
+	}
+
+	var items []Item
+	for _, match := range mastodonArticleRe.FindAllStringSubmatch(body, -1) {
+		block := match[1]
+		if mastodonBoostRe.MatchString(block) {
+			// Skip boosts/reblogs; only surface original statuses.
+			continue
+		}
+		link := ""
+		if lm := mastodonLinkRe.FindStringSubmatch(block); lm != nil {
+			link = lm[1]
+		}
+		text := ""
+		if cm := mastodonContentRe.FindStringSubmatch(block); cm != nil {
+			text = htmlTagRe.ReplaceAllString(cm[1], " ")
+			text = collapseSpaces(text)
+		}
+		if text == "" && link == "" {
+			continue
+		}
+		items = append(items, Item{
+			Title:      truncate(text, 140),
+			Link:       link,
+			SourceName: m.name,
+		})
+	}
+	return items, nil
+}
+
+func collapseSpaces(s string) string {
+	return regexp.MustCompile(`\s+`).ReplaceAllString(s, " ")
+}
+
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTruncateStripsTrailingWhitespace guards against the infinite loop that
+// used to live in a hand-rolled trimSpace: truncate must terminate and strip
+// trailing whitespace even when the input is nothing but whitespace.
+func TestTruncateStripsTrailingWhitespace(t *testing.T) {
+	cases := map[string]string{
+		"":             "",
+		" ":            "",
+		"hello   ":     "hello",
+		"   hello   ":  "hello",
+	}
+	for in, want := range cases {
+		done := make(chan string, 1)
+		go func(in string) { done <- truncate(in, 140) }(in)
+		select {
+		case got := <-done:
+			if got != want {
+				t.Errorf("truncate(%q, 140) = %q, want %q", in, got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("truncate(%q, 140) did not return within 2s (infinite loop?)", in)
+		}
+	}
+}
+
+// TestStatusTrackerLifetimeCountersNeverDecrease guards the Prometheus
+// counter exported at /metrics: SuccessCount/ErrorCount must keep growing
+// even once more than statusWindow attempts have been recorded and older
+// entries have aged out of Recent.
+func TestStatusTrackerLifetimeCountersNeverDecrease(t *testing.T) {
+	var tr statusTracker
+	for i := 0; i < statusWindow+5; i++ {
+		tr.record(http.StatusOK, 0, nil)
+	}
+	for i := 0; i < 3; i++ {
+		tr.record(http.StatusInternalServerError, 0, errServer)
+	}
+
+	status := tr.LastStatus()
+	if status.SuccessCount != uint64(statusWindow+5) {
+		t.Errorf("SuccessCount = %d, want %d", status.SuccessCount, statusWindow+5)
+	}
+	if status.ErrorCount != 3 {
+		t.Errorf("ErrorCount = %d, want 3", status.ErrorCount)
+	}
+	if len(status.Recent) != statusWindow {
+		t.Errorf("Recent len = %d, want capped at %d", len(status.Recent), statusWindow)
+	}
+}
+
+var errServer = fmt.Errorf("server error")
+
+// fakeHTTPDoer is a canned httpDoer used to test Source adapters without a
+// real network round-trip.
+type fakeHTTPDoer struct {
+	status int
+	body   string
+}
+
+func (f fakeHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: f.status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(f.body)),
+	}, nil
+}
+
+func TestMastodonSourceFetchSkipsBoostsAndParsesArticles(t *testing.T) {
+	const page = `
+<article class="status">
+  <div class="status__content">Hello <b>world</b>   </div>
+  <a href="https://mastodon.social/@alice/1" class="status__relative-time">now</a>
+</article>
+<article class="status">
+  <div class="status__prepend">boosted</div>
+  <div class="status__content">Should be skipped</div>
+  <a href="https://mastodon.social/@alice/2" class="status__relative-time">now</a>
+</article>
+`
+	m := NewMastodonSource("Test Mastodon", "https://mastodon.social/@alice", 30)
+	m.client = fakeHTTPDoer{status: http.StatusOK, body: page}
+	m.cache = NewFeedCache(t.TempDir())
+
+	items, err := m.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("got %d items, want 1 (boost should be skipped): %+v", len(items), items)
+	}
+	if items[0].Title != "Hello world" {
+		t.Errorf("Title = %q, want %q", items[0].Title, "Hello world")
+	}
+	if items[0].Link != "https://mastodon.social/@alice/1" {
+		t.Errorf("Link = %q, want %q", items[0].Link, "https://mastodon.social/@alice/1")
+	}
+}
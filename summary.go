@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Summary is the one typed representation of "the latest fetch pass" that
+// every renderer (grouped HTML, river HTML, JSON, Atom) builds its output
+// from, so adding a new output format never means re-fetching or
+// re-deriving the data differently.
+type Summary struct {
+	UpdatedAt time.Time       `json:"updated_at"`
+	Sources   []SourceSummary `json:"sources"`
+}
+
+// SourceSummary is one source's contribution to a Summary.
+type SourceSummary struct {
+	Name       string        `json:"name"`
+	Title      string        `json:"title"`
+	Link       string        `json:"link"`
+	Items      []ItemSummary `json:"items"`
+	LastStatus StatusSummary `json:"last_status"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// ItemSummary is one headline within a SourceSummary.
+type ItemSummary struct {
+	Title       string    `json:"title"`
+	Link        string    `json:"link"`
+	Published   string    `json:"published,omitempty"`
+	PublishedAt time.Time `json:"-"` // resolved timestamp used for sorting; see mergedItems
+	Source      string    `json:"source"`
+	Description string    `json:"-"` // raw, unsanitized; rendered on demand via renderDescription
+}
+
+// StatusSummary mirrors SourceStatus in a JSON-friendly shape.
+type StatusSummary struct {
+	LastSuccess    time.Time `json:"last_success,omitempty"`
+	SuccessRate    float64   `json:"success_rate"`
+	HTTPStatus     int       `json:"http_status"`
+	ResponseTimeMS int64     `json:"response_time_ms"`
+}
+
+// buildSummary runs fetchAllSources and promotes the results into a Summary.
+func buildSummary(ctx context.Context) Summary {
+	results := fetchAllSources(ctx)
+
+	summary := Summary{
+		UpdatedAt: time.Now(),
+		Sources:   make([]SourceSummary, 0, len(results)),
+	}
+
+	for _, res := range results {
+		status := res.Source.LastStatus()
+		ss := SourceSummary{
+			Name:  res.Source.Title(),
+			Title: res.Source.Title(),
+			Link:  sourceLink(res.Source),
+			LastStatus: StatusSummary{
+				LastSuccess:    status.LastSuccess,
+				SuccessRate:    status.SuccessRate(),
+				HTTPStatus:     status.HTTPStatus,
+				ResponseTimeMS: status.ResponseTime.Milliseconds(),
+			},
+		}
+		if res.Err != nil {
+			ss.Error = res.Err.Error()
+		}
+		for _, item := range res.Items {
+			ss.Items = append(ss.Items, ItemSummary{
+				Title:       item.Title,
+				Link:        item.Link,
+				Published:   formatItemTime(item),
+				PublishedAt: itemTime(item),
+				Source:      item.SourceName,
+				Description: item.Description,
+			})
+		}
+		summary.Sources = append(summary.Sources, ss)
+	}
+
+	return summary
+}
+
+// linker is implemented by Sources that have a natural home URL to report
+// as SourceSummary.Link (every adapter we have does).
+type linker interface {
+	Link() string
+}
+
+func sourceLink(src Source) string {
+	if l, ok := src.(linker); ok {
+		return l.Link()
+	}
+	return ""
+}
+
+// formatItemTime renders an item's resolved timestamp as RFC3339, or ""
+// if it has none.
+func formatItemTime(item Item) string {
+	t := itemTime(item)
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// renderSummaryMarkdown renders the grouped, per-source view (the original
+// "## Source Name" sections) from a Summary.
+func renderSummaryMarkdown(summary Summary) string {
+	var out strings.Builder
+	var fetchErrors []string
+
+	for _, src := range summary.Sources {
+		if src.Error != "" {
+			fetchErrors = append(fetchErrors, fmt.Sprintf("Failed to fetch %s: %s", src.Title, src.Error))
+			continue
+		}
+
+		out.WriteString(fmt.Sprintf("## %s %s\n\n", src.Title, statusBadge(src.LastStatus)))
+		count := 0
+		for _, item := range src.Items {
+			if count >= maxItemsPerFeed {
+				break
+			}
+			out.WriteString(fmt.Sprintf("*   [%s](%s)\n", sanitizeItemTitle(item.Title), sanitizeItemLink(item.Link)))
+			if desc := renderDescription(item.Description, itemDescriptionMode); desc != "" {
+				out.WriteString(fmt.Sprintf("    %s\n", desc))
+			}
+			count++
+		}
+		out.WriteString("\n")
+	}
+
+	if len(fetchErrors) > 0 {
+		out.WriteString("\n---\n**Errors during fetch:**\n")
+		for _, errMsg := range fetchErrors {
+			out.WriteString(fmt.Sprintf("*   %s\n", errMsg))
+		}
+	}
+
+	return out.String()
+}
+
+// statusBadge renders the same short per-source health indicator as
+// healthBadge, from a StatusSummary instead of a live SourceStatus.
+func statusBadge(status StatusSummary) string {
+	return fmt.Sprintf("<sub>(%.0f%% ok, %dms)</sub>", status.SuccessRate*100, status.ResponseTimeMS)
+}
+
+// mergedItems flattens every (non-errored) source's items into one list
+// sorted by Published descending and capped at riverCap, for the river view
+// and the merged Atom feed.
+func mergedItems(summary Summary) []ItemSummary {
+	var items []ItemSummary
+	for _, src := range summary.Sources {
+		if src.Error != "" {
+			continue
+		}
+		items = append(items, src.Items...)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		// Compare the resolved time.Time (see itemTime in river.go), not the
+		// formatted Published string: RFC3339 strings only sort the same as
+		// their timestamps when every item uses the same UTC offset, which
+		// feeds don't guarantee.
+		return items[i].PublishedAt.After(items[j].PublishedAt)
+	})
+
+	if len(items) > riverCap {
+		items = items[:riverCap]
+	}
+	return items
+}
+
+// renderRiverMarkdown renders the merged, chronologically sorted view (see
+// chunk0-4) from a Summary instead of raw fetch results.
+func renderRiverMarkdown(summary Summary) string {
+	items := mergedItems(summary)
+
+	var out strings.Builder
+	out.WriteString("## River of News\n\n")
+	for _, item := range items {
+		out.WriteString(fmt.Sprintf("*   [%s](%s) — *%s*\n", sanitizeItemTitle(item.Title), sanitizeItemLink(item.Link), item.Source))
+		if desc := renderDescription(item.Description, itemDescriptionMode); desc != "" {
+			out.WriteString(fmt.Sprintf("    %s\n", desc))
+		}
+	}
+	return out.String()
+}